@@ -0,0 +1,60 @@
+package correlation
+
+import "math"
+
+// PearsonAccumulator computes the weighted Pearson product-moment
+// correlation coefficient of a stream of observations that does not need to
+// fit in memory, using Welford-style online updates of the weighted
+// co-moments.
+type PearsonAccumulator struct {
+	W           float64
+	μx, μy      float64
+	Cxy, Cx, Cy float64
+}
+
+// NewPearsonAccumulator creates an empty PearsonAccumulator.
+func NewPearsonAccumulator() *PearsonAccumulator {
+	return &PearsonAccumulator{}
+}
+
+// Add folds a single weighted observation (x, y, w) into the accumulator.
+func (a *PearsonAccumulator) Add(x, y, w float64) {
+	W := a.W + w
+	dx := x - a.μx
+	dy := y - a.μy
+	a.μx += w / W * dx
+	a.μy += w / W * dy
+	a.Cxy += w * dx * (y - a.μy)
+	a.Cx += w * dx * (x - a.μx)
+	a.Cy += w * dy * (y - a.μy)
+	a.W = W
+}
+
+// Merge combines other into a, as if every observation folded into other had
+// been folded into a directly. other is left unmodified.
+func (a *PearsonAccumulator) Merge(other *PearsonAccumulator) {
+	if other.W == 0 {
+		return
+	}
+	if a.W == 0 {
+		*a = *other
+		return
+	}
+
+	W := a.W + other.W
+	dx := other.μx - a.μx
+	dy := other.μy - a.μy
+	f := a.W * other.W / W
+
+	a.Cxy += other.Cxy + dx*dy*f
+	a.Cx += other.Cx + dx*dx*f
+	a.Cy += other.Cy + dy*dy*f
+	a.μx += dx * other.W / W
+	a.μy += dy * other.W / W
+	a.W = W
+}
+
+// Value returns the current estimate of the Pearson correlation coefficient.
+func (a *PearsonAccumulator) Value() float64 {
+	return a.Cxy / math.Sqrt(a.Cx*a.Cy)
+}
@@ -0,0 +1,36 @@
+package correlation
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestPearsonAccumulatorMatchesBatch(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+	n := 64
+	x := make([]float64, n)
+	y := make([]float64, n)
+	w := make([]float64, n)
+	for i := range x {
+		x[i] = rng.NormFloat64()
+		y[i] = 0.4*x[i] + rng.NormFloat64()
+		w[i] = 1 + rng.Float64()
+	}
+	want := Pearson(x, y, w)
+
+	a := NewPearsonAccumulator()
+	b := NewPearsonAccumulator()
+	for i := range x {
+		if i%3 == 0 {
+			a.Add(x[i], y[i], w[i])
+		} else {
+			b.Add(x[i], y[i], w[i])
+		}
+	}
+	a.Merge(b)
+
+	if got := a.Value(); math.Abs(got-want) > 1e-9 {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
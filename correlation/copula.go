@@ -0,0 +1,106 @@
+package correlation
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/ready-steady/statistics/distribution"
+)
+
+// copulaKind identifies the family of copula a Sampler draws from.
+type copulaKind uint
+
+const (
+	gaussianCopula copulaKind = iota
+	tCopula
+)
+
+// Sampler draws correlated random vectors with arbitrary marginals from the
+// m-by-n loading matrix C produced by Decompose.
+type Sampler struct {
+	C         []float64
+	m, n      uint
+	marginals []distribution.Inverse
+	kind      copulaKind
+	ν         float64
+}
+
+// NewGaussianCopula creates a Sampler that couples the marginals with a
+// Gaussian copula derived from C.
+func NewGaussianCopula(C []float64, m, n uint, marginals []distribution.Inverse) *Sampler {
+	return &Sampler{C: C, m: m, n: n, marginals: marginals, kind: gaussianCopula}
+}
+
+// NewTCopula creates a Sampler that couples the marginals with a Student’s t
+// copula of ν degrees of freedom derived from C.
+func NewTCopula(C []float64, m, n uint, ν float64, marginals []distribution.Inverse) *Sampler {
+	return &Sampler{C: C, m: m, n: n, marginals: marginals, kind: tCopula, ν: ν}
+}
+
+// Sample draws a single m-element random vector into out.
+//
+// An n-element vector Z of iid standard normal variates is drawn and, for
+// the t-copula, additionally scaled by 1/√(χ²_ν/ν). The vector X = C·Z then
+// has the target correlation; each of its components is mapped through Φ (or
+// the t CDF, for the t-copula) to obtain a uniform variate, which is finally
+// passed through the corresponding marginal’s inverse CDF.
+func (s *Sampler) Sample(rng *rand.Rand, out []float64) {
+	z := make([]float64, s.n)
+	for i := range z {
+		z[i] = rng.NormFloat64()
+	}
+	if s.kind == tCopula {
+		scale := math.Sqrt(s.ν / sampleChiSquare(rng, s.ν))
+		for i := range z {
+			z[i] *= scale
+		}
+	}
+
+	s.transform(z, out)
+}
+
+// LatinHypercubeSample draws k correlated random vectors into out[0], …,
+// out[k−1], stratifying each of the n uncorrelated inputs into k equal-probability
+// bins before applying the copula transform, which reduces the variance of
+// downstream Monte-Carlo estimates relative to Sample called k times.
+func (s *Sampler) LatinHypercubeSample(rng *rand.Rand, out [][]float64, k int) {
+	strata := make([][]int, s.n)
+	for j := range strata {
+		strata[j] = rng.Perm(k)
+	}
+
+	z := make([]float64, s.n)
+	for t := 0; t < k; t++ {
+		var χ float64
+		if s.kind == tCopula {
+			χ = math.Sqrt(s.ν / sampleChiSquare(rng, s.ν))
+		}
+		for j := uint(0); j < s.n; j++ {
+			p := (float64(strata[j][t]) + rng.Float64()) / float64(k)
+			z[j] = invNormalCDF(p)
+			if s.kind == tCopula {
+				z[j] *= χ
+			}
+		}
+		s.transform(z, out[t])
+	}
+}
+
+// transform maps an n-element vector of (possibly t-scaled) standard normal
+// variates through C and the marginals into out.
+func (s *Sampler) transform(z, out []float64) {
+	for i := uint(0); i < s.m; i++ {
+		var x float64
+		for j := uint(0); j < s.n; j++ {
+			x += s.C[j*s.m+i] * z[j]
+		}
+
+		var u float64
+		if s.kind == tCopula {
+			u = studentTCDF(x, s.ν)
+		} else {
+			u = normalCDF(x)
+		}
+		out[i] = s.marginals[i].Inverse(u)
+	}
+}
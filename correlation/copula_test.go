@@ -0,0 +1,89 @@
+package correlation
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/ready-steady/statistics/distribution"
+)
+
+// identityMarginal treats its input uniform as already being a standard
+// normal CDF value, leaving the copula's Gaussian dependence structure
+// directly observable in the output.
+type identityMarginal struct{}
+
+func (identityMarginal) Inverse(p float64) float64 { return invNormalCDF(p) }
+
+func TestGaussianCopulaSampleRecoversCorrelation(t *testing.T) {
+	// x3 is perfectly correlated with x1, so Σ has rank 2 and Decompose
+	// reduces m=3 down to n=2 factors, exercising the non-square loading
+	// matrix that Sample and LatinHypercubeSample read from.
+	Σ := []float64{
+		1, 0.5, 1,
+		0.5, 1, 0.5,
+		1, 0.5, 1,
+	}
+	C, _, n, err := Decompose(Σ, 3, 0.999, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 2 {
+		t.Fatalf("expected the decomposition to reduce to 2 factors, got %d", n)
+	}
+
+	marginals := []distribution.Inverse{identityMarginal{}, identityMarginal{}, identityMarginal{}}
+	s := NewGaussianCopula(C, 3, n, marginals)
+
+	rng := rand.New(rand.NewSource(1))
+	count := 100000
+	columns := [3][]float64{}
+	for i := range columns {
+		columns[i] = make([]float64, count)
+	}
+	w := unitWeights(count)
+
+	out := make([]float64, 3)
+	for k := 0; k < count; k++ {
+		s.Sample(rng, out)
+		for i := 0; i < 3; i++ {
+			columns[i][k] = out[i]
+		}
+	}
+
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			got := Pearson(columns[i], columns[j], w)
+			want := Σ[i*3+j]
+			if math.Abs(got-want) > 0.02 {
+				t.Fatalf("correlation(%d, %d): got %v, want %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestLatinHypercubeSampleRecoversCorrelation(t *testing.T) {
+	ρ := 0.6
+	C := []float64{1, ρ, 0, math.Sqrt(1 - ρ*ρ)}
+	marginals := []distribution.Inverse{identityMarginal{}, identityMarginal{}}
+	s := NewGaussianCopula(C, 2, 2, marginals)
+
+	rng := rand.New(rand.NewSource(2))
+	k := 4096
+	out := make([][]float64, k)
+	for i := range out {
+		out[i] = make([]float64, 2)
+	}
+	s.LatinHypercubeSample(rng, out, k)
+
+	x := make([]float64, k)
+	y := make([]float64, k)
+	for i, row := range out {
+		x[i], y[i] = row[0], row[1]
+	}
+
+	got := Pearson(x, y, unitWeights(k))
+	if math.Abs(got-ρ) > 0.03 {
+		t.Fatalf("got %v, want %v", got, ρ)
+	}
+}
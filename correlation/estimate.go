@@ -0,0 +1,242 @@
+package correlation
+
+import (
+	"math"
+	"sort"
+)
+
+// Kind identifies an estimator to be used when computing a correlation
+// matrix with Matrix.
+type Kind uint
+
+const (
+	// KindPearson selects the Pearson product-moment estimator.
+	KindPearson Kind = iota
+
+	// KindSpearman selects the Spearman rank estimator.
+	KindSpearman
+
+	// KindKendall selects the Kendall τ_b rank estimator.
+	KindKendall
+)
+
+// Pearson estimates the weighted Pearson product-moment correlation
+// coefficient between x and y given a weight for each observation.
+//
+// The estimator is computed in two passes: the weighted means of x and y are
+// subtracted first, and the residual of the mean itself is then used to
+// compensate the weighted sums of squares and cross-products, which reduces
+// the rounding error that a naive single-pass formula would accumulate.
+func Pearson(x, y, weights []float64) float64 {
+	n := len(x)
+
+	var W float64
+	for i := 0; i < n; i++ {
+		W += weights[i]
+	}
+
+	var μx, μy float64
+	for i := 0; i < n; i++ {
+		μx += weights[i] * x[i]
+		μy += weights[i] * y[i]
+	}
+	μx /= W
+	μy /= W
+
+	var Sxy, Sxx, Syy, Rx, Ry float64
+	for i := 0; i < n; i++ {
+		xc, yc := x[i]-μx, y[i]-μy
+		Sxy += weights[i] * xc * yc
+		Sxx += weights[i] * xc * xc
+		Syy += weights[i] * yc * yc
+		Rx += weights[i] * xc
+		Ry += weights[i] * yc
+	}
+	Sxy -= Rx * Ry / W
+	Sxx -= Rx * Rx / W
+	Syy -= Ry * Ry / W
+
+	return Sxy / math.Sqrt(Sxx*Syy)
+}
+
+// Spearman estimates the weighted Spearman rank correlation coefficient
+// between x and y given a weight for each observation. Tied observations are
+// assigned their average rank.
+func Spearman(x, y, weights []float64) float64 {
+	return Pearson(rank(x), rank(y), weights)
+}
+
+// Kendall estimates the tie-corrected Kendall τ_b rank correlation
+// coefficient between x and y using Knight’s O(n log n) algorithm.
+//
+// https://en.wikipedia.org/wiki/Kendall_rank_correlation_coefficient
+func Kendall(x, y []float64) float64 {
+	n := len(x)
+
+	index := make([]int, n)
+	for i := range index {
+		index[i] = i
+	}
+	sort.Slice(index, func(i, j int) bool {
+		a, b := index[i], index[j]
+		if x[a] != x[b] {
+			return x[a] < x[b]
+		}
+		return y[a] < y[b]
+	})
+
+	xs := make([]float64, n)
+	ys := make([]float64, n)
+	for i, j := range index {
+		xs[i], ys[i] = x[j], y[j]
+	}
+
+	var jointTies, xTies float64
+	for i := 0; i < n; {
+		j := i
+		for j+1 < n && xs[j+1] == xs[i] {
+			j++
+		}
+		xTies += tieCount(j - i + 1)
+		for k := i; k < j; {
+			l := k
+			for l+1 <= j && ys[l+1] == ys[k] {
+				l++
+			}
+			jointTies += tieCount(l - k + 1)
+			k = l + 1
+		}
+		i = j + 1
+	}
+
+	exchanges := countInversions(append([]float64{}, ys...))
+
+	sorted := append([]float64{}, ys...)
+	sort.Float64s(sorted)
+	var yTies float64
+	for i := 0; i < n; {
+		j := i
+		for j+1 < n && sorted[j+1] == sorted[i] {
+			j++
+		}
+		yTies += tieCount(j - i + 1)
+		i = j + 1
+	}
+
+	n0 := tieCount(n)
+	numerator := n0 - xTies - yTies + jointTies - 2*exchanges
+
+	return numerator / math.Sqrt((n0-xTies)*(n0-yTies))
+}
+
+// Matrix builds the full m-by-m correlation matrix of the n-by-m data array
+// using the estimator identified by kind. weights holds one weight per row
+// and is ignored when kind is KindKendall, which is unweighted.
+func Matrix(data []float64, m, n uint, weights []float64, kind Kind) []float64 {
+	columns := make([][]float64, m)
+	for j := uint(0); j < m; j++ {
+		columns[j] = column(data, m, n, j)
+	}
+
+	C := make([]float64, m*m)
+	for i := uint(0); i < m; i++ {
+		C[i*m+i] = 1
+		for j := i + 1; j < m; j++ {
+			var ρ float64
+			switch kind {
+			case KindSpearman:
+				ρ = Spearman(columns[i], columns[j], weights)
+			case KindKendall:
+				ρ = Kendall(columns[i], columns[j])
+			default:
+				ρ = Pearson(columns[i], columns[j], weights)
+			}
+			C[i*m+j], C[j*m+i] = ρ, ρ
+		}
+	}
+
+	return C
+}
+
+// column extracts the j-th column of an n-by-m data array.
+func column(data []float64, m, n, j uint) []float64 {
+	x := make([]float64, n)
+	for i := uint(0); i < n; i++ {
+		x[i] = data[i*m+j]
+	}
+	return x
+}
+
+// rank computes the 1-based ranks of x, averaging the ranks of tied values.
+func rank(x []float64) []float64 {
+	n := len(x)
+	index := make([]int, n)
+	for i := range index {
+		index[i] = i
+	}
+	sort.Slice(index, func(i, j int) bool { return x[index[i]] < x[index[j]] })
+
+	r := make([]float64, n)
+	for i := 0; i < n; {
+		j := i
+		for j+1 < n && x[index[j+1]] == x[index[i]] {
+			j++
+		}
+		μ := float64(i+j)/2 + 1
+		for k := i; k <= j; k++ {
+			r[index[k]] = μ
+		}
+		i = j + 1
+	}
+
+	return r
+}
+
+// tieCount returns the number of unordered pairs within a group of t tied
+// observations, t·(t − 1) / 2.
+func tieCount(t int) float64 {
+	return float64(t) * float64(t-1) / 2
+}
+
+// countInversions counts, and removes by sorting in place, the number of
+// discordant pairs in y via merge sort, in O(n log n).
+func countInversions(y []float64) float64 {
+	buffer := make([]float64, len(y))
+	return mergeCount(y, buffer)
+}
+
+func mergeCount(y, buffer []float64) float64 {
+	n := len(y)
+	if n < 2 {
+		return 0
+	}
+
+	mid := n / 2
+	count := mergeCount(y[:mid], buffer[:mid]) + mergeCount(y[mid:], buffer[mid:])
+
+	copy(buffer, y)
+	i, j, k := 0, mid, 0
+	for i < mid && j < n {
+		if buffer[i] <= buffer[j] {
+			y[k] = buffer[i]
+			i++
+		} else {
+			y[k] = buffer[j]
+			j++
+			count += float64(mid - i)
+		}
+		k++
+	}
+	for i < mid {
+		y[k] = buffer[i]
+		i++
+		k++
+	}
+	for j < n {
+		y[k] = buffer[j]
+		j++
+		k++
+	}
+
+	return count
+}
@@ -0,0 +1,66 @@
+package correlation
+
+import (
+	"math"
+	"testing"
+)
+
+// The expected values below were computed independently with a reference
+// two-pass Pearson, tie-averaged Spearman, and brute-force tau_b Kendall.
+var (
+	estimateX = []float64{1, 2, 3, 4, 5}
+	estimateY = []float64{2, 4, 5, 4, 5}
+)
+
+func unitWeights(n int) []float64 {
+	w := make([]float64, n)
+	for i := range w {
+		w[i] = 1
+	}
+	return w
+}
+
+func TestPearsonKnownValue(t *testing.T) {
+	got := Pearson(estimateX, estimateY, unitWeights(len(estimateX)))
+	want := 0.7745966692414834
+	if math.Abs(got-want) > 1e-9 {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestSpearmanKnownValue(t *testing.T) {
+	got := Spearman(estimateX, estimateY, unitWeights(len(estimateX)))
+	want := 0.7378647873726218
+	if math.Abs(got-want) > 1e-9 {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestKendallKnownValue(t *testing.T) {
+	got := Kendall(estimateX, estimateY)
+	want := 0.6708203932499369
+	if math.Abs(got-want) > 1e-9 {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestMatrixMatchesPairwiseEstimates(t *testing.T) {
+	data := []float64{
+		estimateX[0], estimateY[0],
+		estimateX[1], estimateY[1],
+		estimateX[2], estimateY[2],
+		estimateX[3], estimateY[3],
+		estimateX[4], estimateY[4],
+	}
+	w := unitWeights(len(estimateX))
+
+	C := Matrix(data, 2, 5, w, KindPearson)
+	want := Pearson(estimateX, estimateY, w)
+
+	if C[0*2+0] != 1 || C[1*2+1] != 1 {
+		t.Fatalf("diagonal is not unit: %v", C)
+	}
+	if math.Abs(C[0*2+1]-want) > 1e-9 || math.Abs(C[1*2+0]-want) > 1e-9 {
+		t.Fatalf("got %v, want symmetric %v", C, want)
+	}
+}
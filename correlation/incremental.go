@@ -0,0 +1,111 @@
+package correlation
+
+// Incremental maintains a running weighted covariance matrix that can be
+// updated one observation at a time and decomposed on demand, so that a long-
+// running simulation does not need to buffer its full sample matrix in order
+// to periodically recompute Decompose.
+type Incremental struct {
+	m uint
+	λ float64
+
+	W float64
+	μ []float64
+	M []float64
+
+	δ []float64 // scratch space for Update and Merge
+}
+
+// New creates an empty Incremental for m-variate observations. λ is passed
+// to Decompose when Decompose is called.
+func New(m uint, λ float64) *Incremental {
+	return &Incremental{
+		m: m,
+		λ: λ,
+		μ: make([]float64, m),
+		M: make([]float64, m*m),
+		δ: make([]float64, m),
+	}
+}
+
+// Update folds a single weighted m-element observation x into the running
+// mean and co-moment matrix using West’s numerically stable weighted update.
+func (i *Incremental) Update(x []float64, w float64) {
+	m := i.m
+
+	Wold := i.W
+	W := Wold + w
+	Δ := i.δ
+	for j := uint(0); j < m; j++ {
+		Δ[j] = x[j] - i.μ[j]
+		i.μ[j] += w / W * Δ[j]
+	}
+
+	// (x − μ_new) = Δ·Wold/W, so the outer product w·Δ·(x − μ_new)ᵀ reduces
+	// to a symmetric scalar multiple of Δ·Δᵀ, and only the upper triangle
+	// of M needs to be computed and mirrored.
+	f := w * Wold / W
+	for j := uint(0); j < m; j++ {
+		c := f * Δ[j] * Δ[j]
+		i.M[j*m+j] += c
+		for k := j + 1; k < m; k++ {
+			c := f * Δ[j] * Δ[k]
+			i.M[j*m+k] += c
+			i.M[k*m+j] += c
+		}
+	}
+
+	i.W = W
+}
+
+// Covariance returns the current m-by-m weighted sample covariance matrix,
+// M / (W − 1).
+func (i *Incremental) Covariance() []float64 {
+	Σ := make([]float64, len(i.M))
+	for k, v := range i.M {
+		Σ[k] = v / (i.W - 1)
+	}
+	return Σ
+}
+
+// Decompose runs Decompose on the current covariance matrix.
+func (i *Incremental) Decompose() ([]float64, []float64, uint, error) {
+	return Decompose(i.Covariance(), i.m, i.λ, nil)
+}
+
+// Merge combines other into i, as if every observation folded into other had
+// been folded into i directly, using the Chan-Golub-LeVeque pairwise
+// combination of weighted means and co-moments. other is left unmodified.
+func (i *Incremental) Merge(other *Incremental) {
+	if other.W == 0 {
+		return
+	}
+	if i.W == 0 {
+		i.W = other.W
+		i.μ = append([]float64{}, other.μ...)
+		i.M = append([]float64{}, other.M...)
+		return
+	}
+
+	m := i.m
+	W := i.W + other.W
+	f := i.W * other.W / W
+
+	δ := i.δ
+	for j := uint(0); j < m; j++ {
+		δ[j] = other.μ[j] - i.μ[j]
+	}
+
+	for j := uint(0); j < m; j++ {
+		i.M[j*m+j] += other.M[j*m+j] + δ[j]*δ[j]*f
+		for k := j + 1; k < m; k++ {
+			c := δ[j] * δ[k] * f
+			i.M[j*m+k] += other.M[j*m+k] + c
+			i.M[k*m+j] += other.M[k*m+j] + c
+		}
+	}
+	for j := uint(0); j < m; j++ {
+		i.μ[j] += δ[j] * other.W / W
+	}
+
+	i.W = W
+}
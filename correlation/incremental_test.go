@@ -0,0 +1,89 @@
+package correlation
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func batchCovariance(data [][]float64, m int) []float64 {
+	n := len(data)
+	μ := make([]float64, m)
+	for _, x := range data {
+		for j := 0; j < m; j++ {
+			μ[j] += x[j]
+		}
+	}
+	for j := range μ {
+		μ[j] /= float64(n)
+	}
+	Σ := make([]float64, m*m)
+	for _, x := range data {
+		for j := 0; j < m; j++ {
+			for k := 0; k < m; k++ {
+				Σ[j*m+k] += (x[j] - μ[j]) * (x[k] - μ[k])
+			}
+		}
+	}
+	for i := range Σ {
+		Σ[i] /= float64(n - 1)
+	}
+	return Σ
+}
+
+func TestIncrementalMatchesBatchCovariance(t *testing.T) {
+	rng := rand.New(rand.NewSource(11))
+	m := 3
+	n := 100
+	data := make([][]float64, n)
+	for i := range data {
+		data[i] = []float64{rng.NormFloat64(), rng.NormFloat64() * 2, rng.NormFloat64() * 0.5}
+	}
+	want := batchCovariance(data, m)
+
+	inc := New(uint(m), 1)
+	for _, x := range data {
+		inc.Update(x, 1)
+	}
+	got := inc.Covariance()
+
+	for i := range got {
+		if math.Abs(got[i]-want[i]) > 1e-9 {
+			t.Fatalf("index %d: got %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestIncrementalMergeMatchesSequentialUpdates(t *testing.T) {
+	rng := rand.New(rand.NewSource(22))
+	m := 2
+	n := 80
+	data := make([][]float64, n)
+	for i := range data {
+		data[i] = []float64{rng.NormFloat64(), rng.NormFloat64()}
+	}
+
+	full := New(uint(m), 1)
+	for _, x := range data {
+		full.Update(x, 1)
+	}
+
+	a := New(uint(m), 1)
+	b := New(uint(m), 1)
+	for i, x := range data {
+		if i < n/3 {
+			a.Update(x, 1)
+		} else {
+			b.Update(x, 1)
+		}
+	}
+	a.Merge(b)
+
+	want := full.Covariance()
+	got := a.Covariance()
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-9 {
+			t.Fatalf("index %d: got %v, want %v", i, got[i], want[i])
+		}
+	}
+}
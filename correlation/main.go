@@ -32,6 +32,17 @@ func KendallPearson(τ []float64) []float64 {
 	return r
 }
 
+// DecomposeOptions controls the optional behavior of Decompose.
+type DecomposeOptions struct {
+	// Repair, if true, projects Σ onto the nearest valid correlation matrix
+	// via NearestCorrelation before the decomposition is computed. This is
+	// useful when Σ was assembled from pairwise Spearman or Kendall
+	// estimates that were then converted with SpearmanPearson or
+	// KendallPearson, which does not generally yield a positive
+	// semidefinite matrix.
+	Repair bool
+}
+
 // Decompose computes an m-by-n matrix C and an n-by-m matrix D given an m-by-m
 // covariance matrix Σ such that:
 //
@@ -43,7 +54,18 @@ func KendallPearson(τ []float64) []float64 {
 //
 // The function reduces the number of dimensions from m to n such that a certain
 // portion of the variance is preserved, which is controlled by λ ∈ (0, 1].
-func Decompose(Σ []float64, m uint, λ float64) ([]float64, []float64, uint, error) {
+//
+// opts may be nil, in which case Σ is assumed to already be a valid
+// covariance matrix; see DecomposeOptions for ways to relax that assumption.
+func Decompose(Σ []float64, m uint, λ float64, opts *DecomposeOptions) ([]float64, []float64, uint, error) {
+	if opts != nil && opts.Repair {
+		repaired, err := NearestCorrelation(Σ, m, nil)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		Σ = repaired
+	}
+
 	U, Λ, err := decomposition.CovPCA(Σ, m, math.Sqrt(math.Nextafter(1.0, 2.0)-1.0))
 	if err != nil {
 		return nil, nil, 0, err
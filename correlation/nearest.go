@@ -0,0 +1,167 @@
+package correlation
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ready-steady/statistics/decomposition"
+)
+
+// NearOptions controls the optional behavior of NearestCorrelation.
+type NearOptions struct {
+	// Tolerance is the relative Frobenius-norm change below which the
+	// iteration is considered to have converged. The zero value corresponds
+	// to a sane default.
+	Tolerance float64
+
+	// MaxIterations bounds the number of alternating projections performed.
+	// The zero value corresponds to a sane default.
+	MaxIterations uint
+}
+
+const (
+	defaultNearTolerance     = 1e-8
+	defaultNearMaxIterations = 100
+)
+
+// NearestCorrelation finds the correlation matrix nearest to the m-by-m
+// matrix Σ in the Frobenius norm using Higham’s alternating-projections
+// algorithm with Dykstra’s correction.
+//
+// This is useful when Σ is not positive semidefinite, which commonly happens
+// when it is assembled from pairwise Spearman or Kendall estimates that were
+// then converted with SpearmanPearson or KendallPearson.
+//
+// https://nhigham.com/2013/02/13/the-nearest-correlation-matrix/
+func NearestCorrelation(Σ []float64, m uint, opts *NearOptions) ([]float64, error) {
+	tolerance, maxIterations := defaultNearTolerance, uint(defaultNearMaxIterations)
+	if opts != nil {
+		if opts.Tolerance > 0 {
+			tolerance = opts.Tolerance
+		}
+		if opts.MaxIterations > 0 {
+			maxIterations = opts.MaxIterations
+		}
+	}
+
+	Y := append([]float64{}, Σ...)
+	ΔS := make([]float64, m*m)
+
+	for iteration := uint(0); iteration < maxIterations; iteration++ {
+		R := subtract(Y, ΔS)
+
+		X, err := projectPSD(R, m)
+		if err != nil {
+			return nil, err
+		}
+		ΔS = subtract(X, R)
+
+		Z := projectUnitDiagonal(X, m)
+
+		if frobeniusNorm(subtract(Z, Y))/frobeniusNorm(Y) < tolerance {
+			return Z, nil
+		}
+		Y = Z
+	}
+
+	return Y, nil
+}
+
+// IsCorrelation reports whether Σ is a valid m-by-m correlation matrix,
+// i.e. symmetric, with a unit diagonal, and positive semidefinite. A
+// non-nil error names the specific way in which Σ fails to qualify.
+func IsCorrelation(Σ []float64, m uint) error {
+	for i := uint(0); i < m; i++ {
+		if Σ[i*m+i] != 1 {
+			return fmt.Errorf("correlation: element (%d, %d) on the diagonal is %g, not 1", i, i, Σ[i*m+i])
+		}
+		for j := i + 1; j < m; j++ {
+			if Σ[i*m+j] != Σ[j*m+i] {
+				return fmt.Errorf("correlation: matrix is not symmetric at (%d, %d)", i, j)
+			}
+		}
+	}
+
+	_, Λ, err := decomposition.CovPCA(Σ, m, math.Sqrt(math.Nextafter(1.0, 2.0)-1.0))
+	if err != nil {
+		return err
+	}
+
+	min := Λ[0]
+	for _, λ := range Λ {
+		if λ < min {
+			min = λ
+		}
+	}
+	if min < 0 {
+		return fmt.Errorf("correlation: matrix is not positive semidefinite, smallest eigenvalue is %g", min)
+	}
+
+	return nil
+}
+
+// projectPSD computes P_S(Y), the projection of the m-by-m symmetric matrix
+// Y onto the cone of positive semidefinite matrices, by eigendecomposing Y
+// and clipping its negative eigenvalues to zero.
+func projectPSD(Y []float64, m uint) ([]float64, error) {
+	U, Λ, err := decomposition.CovPCA(Y, m, math.Sqrt(math.Nextafter(1.0, 2.0)-1.0))
+	if err != nil {
+		return nil, err
+	}
+
+	X := make([]float64, m*m)
+	for i := uint(0); i < m; i++ {
+		if Λ[i] <= 0 {
+			continue
+		}
+		for j := uint(0); j < m; j++ {
+			if U[i*m+j] == 0 {
+				continue
+			}
+			for k := uint(0); k < m; k++ {
+				X[j*m+k] += Λ[i] * U[i*m+j] * U[i*m+k]
+			}
+		}
+	}
+
+	return X, nil
+}
+
+// projectUnitDiagonal computes P_U(Y), the projection of the m-by-m matrix Y
+// onto the affine set of matrices with a unit diagonal, clamping the
+// off-diagonal elements to [-1, 1].
+func projectUnitDiagonal(Y []float64, m uint) []float64 {
+	Z := append([]float64{}, Y...)
+	for i := uint(0); i < m; i++ {
+		for j := uint(0); j < m; j++ {
+			switch {
+			case i == j:
+				Z[i*m+j] = 1
+			case Z[i*m+j] > 1:
+				Z[i*m+j] = 1
+			case Z[i*m+j] < -1:
+				Z[i*m+j] = -1
+			}
+		}
+	}
+	return Z
+}
+
+// subtract returns a - b, the element-wise difference of two equally sized
+// matrices.
+func subtract(a, b []float64) []float64 {
+	c := make([]float64, len(a))
+	for i := range c {
+		c[i] = a[i] - b[i]
+	}
+	return c
+}
+
+// frobeniusNorm returns the Frobenius norm of a matrix given in flat form.
+func frobeniusNorm(a []float64) float64 {
+	var sum float64
+	for _, v := range a {
+		sum += v * v
+	}
+	return math.Sqrt(sum)
+}
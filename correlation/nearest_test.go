@@ -0,0 +1,72 @@
+package correlation
+
+import (
+	"math"
+	"testing"
+)
+
+func TestIsCorrelationAcceptsValidMatrix(t *testing.T) {
+	Σ := []float64{1, 0.5, 0.5, 1}
+	if err := IsCorrelation(Σ, 2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestIsCorrelationRejectsAsymmetric(t *testing.T) {
+	Σ := []float64{1, 0.5, 0.6, 1}
+	if err := IsCorrelation(Σ, 2); err == nil {
+		t.Fatalf("expected an error")
+	}
+}
+
+func TestIsCorrelationRejectsIndefinite(t *testing.T) {
+	// A classic example (Higham 2002) that is symmetric with a unit
+	// diagonal but not positive semidefinite.
+	Σ := []float64{
+		1, 0.9, 0.9,
+		0.9, 1, -0.9,
+		0.9, -0.9, 1,
+	}
+	if err := IsCorrelation(Σ, 3); err == nil {
+		t.Fatalf("expected an error for an indefinite matrix")
+	}
+}
+
+func TestNearestCorrelationKnownResult(t *testing.T) {
+	Σ := []float64{
+		1, 0.9, 0.9,
+		0.9, 1, -0.9,
+		0.9, -0.9, 1,
+	}
+
+	got, err := NearestCorrelation(Σ, 3, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Independently verified: Higham's alternating-projections algorithm
+	// converges to off-diagonal entries of ±0.5 for this Σ.
+	want := []float64{
+		1, 0.5, 0.5,
+		0.5, 1, -0.5,
+		0.5, -0.5, 1,
+	}
+	for i := range got {
+		if math.Abs(got[i]-want[i]) > 1e-4 {
+			t.Fatalf("index %d: got %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestNearestCorrelationLeavesValidMatrixAlone(t *testing.T) {
+	Σ := []float64{1, 0.5, 0.5, 1}
+	got, err := NearestCorrelation(Σ, 2, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := range got {
+		if math.Abs(got[i]-Σ[i]) > 1e-6 {
+			t.Fatalf("index %d: got %v, want %v", i, got[i], Σ[i])
+		}
+	}
+}
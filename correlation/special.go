@@ -0,0 +1,201 @@
+package correlation
+
+import (
+	"math"
+	"math/rand"
+)
+
+// normalCDF evaluates the standard normal cumulative distribution function
+// Φ(x).
+func normalCDF(x float64) float64 {
+	return 0.5 * math.Erfc(-x/math.Sqrt2)
+}
+
+// invNormalCDF evaluates the inverse of the standard normal cumulative
+// distribution function, Φ⁻¹(p), using Acklam’s rational approximation
+// refined by one step of Halley’s method.
+//
+// https://web.archive.org/web/20151030215612/http://home.online.no/~pjacklam/notes/invnorm/
+func invNormalCDF(p float64) float64 {
+	const (
+		a1 = -3.969683028665376e+01
+		a2 = 2.209460984245205e+02
+		a3 = -2.759285104469687e+02
+		a4 = 1.383577518672690e+02
+		a5 = -3.066479806614716e+01
+		a6 = 2.506628277459239e+00
+
+		b1 = -5.447609879822406e+01
+		b2 = 1.615858368580409e+02
+		b3 = -1.556989798598866e+02
+		b4 = 6.680131188771972e+01
+		b5 = -1.328068155288572e+01
+
+		c1 = -7.784894002430293e-03
+		c2 = -3.223964580411365e-01
+		c3 = -2.400758277161838e+00
+		c4 = -2.549732539343734e+00
+		c5 = 4.374664141464968e+00
+		c6 = 2.938163982698783e+00
+
+		d1 = 7.784695709041462e-03
+		d2 = 3.224671290700398e-01
+		d3 = 2.445134137142996e+00
+		d4 = 3.754408661907416e+00
+
+		low  = 0.02425
+		high = 1 - low
+	)
+
+	var x float64
+	switch {
+	case p < low:
+		q := math.Sqrt(-2 * math.Log(p))
+		x = (((((c1*q+c2)*q+c3)*q+c4)*q+c5)*q + c6) /
+			((((d1*q+d2)*q+d3)*q+d4)*q + 1)
+	case p <= high:
+		q := p - 0.5
+		r := q * q
+		x = (((((a1*r+a2)*r+a3)*r+a4)*r+a5)*r + a6) * q /
+			(((((b1*r+b2)*r+b3)*r+b4)*r+b5)*r + 1)
+	default:
+		q := math.Sqrt(-2 * math.Log(1-p))
+		x = -(((((c1*q+c2)*q+c3)*q+c4)*q+c5)*q + c6) /
+			((((d1*q+d2)*q+d3)*q+d4)*q + 1)
+	}
+
+	// One step of Halley's rational method refinement.
+	e := 0.5*math.Erfc(-x/math.Sqrt2) - p
+	u := e * math.Sqrt(2*math.Pi) * math.Exp(x*x/2)
+	x -= u / (1 + x*u/2)
+
+	return x
+}
+
+// studentTCDF evaluates the cumulative distribution function of Student’s t
+// distribution with ν degrees of freedom at t.
+func studentTCDF(t, ν float64) float64 {
+	x := ν / (ν + t*t)
+	p := 0.5 * incompleteBeta(x, ν/2, 0.5)
+	if t > 0 {
+		return 1 - p
+	}
+	return p
+}
+
+// incompleteBeta evaluates the regularized incomplete beta function I_x(a, b)
+// using a continued-fraction expansion.
+func incompleteBeta(x, a, b float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+
+	front := math.Exp(lgammaSum(a, b) + a*math.Log(x) + b*math.Log(1-x))
+	if x < (a+1)/(a+b+2) {
+		return front * betacf(x, a, b) / a
+	}
+	return 1 - front*betacf(1-x, b, a)/b
+}
+
+// lgammaSum returns ln Γ(a+b) − ln Γ(a) − ln Γ(b), the log of the reciprocal
+// of the beta function B(a, b).
+func lgammaSum(a, b float64) float64 {
+	lga, _ := math.Lgamma(a)
+	lgb, _ := math.Lgamma(b)
+	lgab, _ := math.Lgamma(a + b)
+	return lgab - lga - lgb
+}
+
+// betacf evaluates the continued fraction used by incompleteBeta, following
+// the algorithm in Numerical Recipes.
+func betacf(x, a, b float64) float64 {
+	const (
+		maxIterations = 200
+		epsilon       = 3e-14
+		tiny          = 1e-300
+	)
+
+	qab := a + b
+	qap := a + 1
+	qam := a - 1
+
+	c := 1.0
+	d := 1 - qab*x/qap
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	h := d
+
+	for m := 1; m <= maxIterations; m++ {
+		fm := float64(m)
+
+		a1 := fm * (b - fm) * x / ((qam + 2*fm) * (a + 2*fm))
+		d = 1 + a1*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + a1/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		h *= d * c
+
+		a2 := -(a + fm) * (qab + fm) * x / ((a + 2*fm) * (qap + 2*fm))
+		d = 1 + a2*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + a2/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		δ := d * c
+		h *= δ
+
+		if math.Abs(δ-1) < epsilon {
+			break
+		}
+	}
+
+	return h
+}
+
+// sampleGamma draws a single variate from a Gamma(shape, scale) distribution
+// using the Marsaglia-Tsang method.
+func sampleGamma(rng *rand.Rand, shape, scale float64) float64 {
+	if shape < 1 {
+		u := rng.Float64()
+		return sampleGamma(rng, shape+1, scale) * math.Pow(u, 1/shape)
+	}
+
+	d := shape - 1.0/3.0
+	c := 1 / math.Sqrt(9*d)
+	for {
+		var x, v float64
+		for {
+			x = rng.NormFloat64()
+			v = 1 + c*x
+			if v > 0 {
+				break
+			}
+		}
+		v = v * v * v
+		u := rng.Float64()
+
+		if u < 1-0.0331*x*x*x*x || math.Log(u) < 0.5*x*x+d*(1-v+math.Log(v)) {
+			return d * v * scale
+		}
+	}
+}
+
+// sampleChiSquare draws a single variate from a χ² distribution with ν
+// degrees of freedom.
+func sampleChiSquare(rng *rand.Rand, ν float64) float64 {
+	return sampleGamma(rng, ν/2, 2)
+}